@@ -0,0 +1,121 @@
+package datadog
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceDatadogIncident() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to retrieve information about an existing Datadog incident for use in other resources.",
+		Read:        dataSourceDatadogIncidentRead,
+		Schema: map[string]*schema.Schema{
+			"incident_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the incident to look up.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The title of the incident.",
+			},
+			"customer_impact_scope": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A summary of the impact customers experienced during the incident.",
+			},
+			"customer_impacted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "A flag indicating whether the incident caused customer impact.",
+			},
+			"severity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The incident severity.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the incident.",
+			},
+			"commander_user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the user designated as the incident commander.",
+			},
+			"notification_handles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Notification handles that will be notified of the incident's state changes.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the notified handle.",
+						},
+						"handle": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The handle used for the notification, for example an email address or Slack channel.",
+						},
+					},
+				},
+			},
+			"field": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "A dynamic incident field, for tracking custom incident metadata such as the affected services or detection method.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the field.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the field, one of `dropdown`, `multiselect`, `textbox`, or `metrictag`.",
+						},
+						"value": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The field's value(s).",
+						},
+					},
+				},
+			},
+			"archived": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the incident has been archived.",
+			},
+		},
+	}
+}
+
+func dataSourceDatadogIncidentRead(d *schema.ResourceData, meta interface{}) error {
+	providerConf := meta.(*ProviderConfiguration)
+	datadogClientV2 := providerConf.DatadogClientV2
+	authV2 := providerConf.AuthV2
+
+	incidentID := d.Get("incident_id").(string)
+
+	resp, _, err := datadogClientV2.IncidentsApi.GetIncident(authV2, incidentID)
+	if err != nil {
+		return translateClientError(err, "error querying incident")
+	}
+
+	d.SetId(incidentID)
+
+	if err := updateIncidentState(d, &resp); err != nil {
+		return fmt.Errorf("error setting incident attributes: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package datadog
+
+import (
+	"testing"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceDatadogIncident_UpdateIncidentState(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceDatadogIncident().Schema, map[string]interface{}{
+		"incident_id": "123",
+	})
+
+	handle := datadogV2.NewIncidentNotificationHandleWithDefaults()
+	handle.SetDisplayName("On-call")
+	handle.SetHandle("@oncall")
+
+	stateField := datadogV2.NewIncidentFieldAttributesSingleValueWithDefaults()
+	stateField.SetType(datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_DROPDOWN)
+	stateField.SetValue("active")
+
+	attributes := datadogV2.NewIncidentResponseAttributesWithDefaults()
+	attributes.SetTitle("db outage")
+	attributes.SetCustomerImpacted(true)
+	attributes.SetNotificationHandles([]datadogV2.IncidentNotificationHandle{*handle})
+	attributes.SetFields(map[string]datadogV2.IncidentFieldAttributes{
+		"state": datadogV2.IncidentFieldAttributesSingleValueAsIncidentFieldAttributes(stateField),
+	})
+
+	data := datadogV2.NewIncidentResponseDataWithDefaults()
+	data.SetAttributes(*attributes)
+
+	incident := datadogV2.NewIncidentResponseWithDefaults()
+	incident.SetData(*data)
+
+	if err := updateIncidentState(d, incident); err != nil {
+		t.Fatalf("updateIncidentState returned an error: %v", err)
+	}
+
+	if got := d.Get("title").(string); got != "db outage" {
+		t.Errorf("expected title %q, got %q", "db outage", got)
+	}
+	if got := d.Get("status").(string); got != "active" {
+		t.Errorf("expected status %q, got %q", "active", got)
+	}
+
+	handles := d.Get("notification_handles").([]interface{})
+	if len(handles) != 1 {
+		t.Fatalf("expected 1 notification handle, got %d", len(handles))
+	}
+	got := handles[0].(map[string]interface{})
+	if got["handle"] != "@oncall" || got["display_name"] != "On-call" {
+		t.Errorf("unexpected notification handle: %+v", got)
+	}
+}
@@ -0,0 +1,39 @@
+package datadog
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	authModeAPIKey = "api_key"
+	authModeOAuth2 = "oauth2"
+)
+
+// newOAuth2HTTPClient wraps base with an oauth2 client-credentials transport
+// that attaches and automatically refreshes a bearer token obtained from the
+// configured IdP, for orgs standardizing on short-lived credentials instead
+// of a long-lived APP key.
+func newOAuth2HTTPClient(d *schema.ResourceData, base *http.Client) *http.Client {
+	cfg := clientcredentials.Config{
+		ClientID:     d.Get("client_id").(string),
+		ClientSecret: d.Get("client_secret").(string),
+		TokenURL:     d.Get("token_url").(string),
+		Scopes:       expandStringList(d.Get("scopes").([]interface{})),
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, base)
+	return cfg.Client(ctx)
+}
+
+func expandStringList(raw []interface{}) []string {
+	scopes := make([]string, 0, len(raw))
+	for _, v := range raw {
+		scopes = append(scopes, v.(string))
+	}
+	return scopes
+}
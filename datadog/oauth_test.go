@@ -0,0 +1,48 @@
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestExpandStringList(t *testing.T) {
+	got := expandStringList([]interface{}{"incident_read", "incident_write"})
+	want := []string{"incident_read", "incident_write"}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d scopes, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("scope %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestExpandStringList_Empty(t *testing.T) {
+	if got := expandStringList(nil); len(got) != 0 {
+		t.Errorf("expected no scopes, got %v", got)
+	}
+}
+
+func TestNewOAuth2HTTPClient(t *testing.T) {
+	providerSchema := Provider().(*schema.Provider).Schema
+	d := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"client_id":     "my-client-id",
+		"client_secret": "my-client-secret",
+		"token_url":     "https://idp.example.com/oauth/token",
+		"scopes":        []interface{}{"incident_write"},
+	})
+
+	base := &http.Client{}
+	client := newOAuth2HTTPClient(d, base)
+
+	if client == nil {
+		t.Fatal("expected a non-nil http.Client")
+	}
+	if client.Transport == nil {
+		t.Error("expected the oauth2 client credentials transport to be wired in")
+	}
+}
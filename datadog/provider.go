@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"runtime"
 	"strings"
@@ -15,8 +16,10 @@ import (
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-datadog/internal/secrets"
 	"github.com/terraform-providers/terraform-provider-datadog/version"
 	datadogCommunity "github.com/zorkian/go-datadog-api"
 )
@@ -26,6 +29,67 @@ var (
 	baseIpRangesSubdomain = "ip-ranges"
 )
 
+// defaultSite is used to derive the API host when neither `site` nor the
+// legacy `api_url` attribute is configured.
+const defaultSite = "datadoghq.com"
+
+// resolveAPIURL determines the API host to configure the Datadog clients
+// with. An explicit `api_url` always wins for backward compatibility; it is
+// validated against `site` (when the latter was explicitly set to something
+// other than its default) so conflicting configuration is caught early
+// instead of silently preferring one over the other.
+func resolveAPIURL(apiURL, site string) (string, error) {
+	if site == "" {
+		site = defaultSite
+	}
+
+	if apiURL == "" {
+		return fmt.Sprintf("https://api.%s/", site), nil
+	}
+
+	if site != defaultSite {
+		parsedAPIURL, err := url.Parse(apiURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid API Url : %v", err)
+		}
+		if expectedHost := fmt.Sprintf("api.%s", site); parsedAPIURL.Host != "" && parsedAPIURL.Host != expectedHost {
+			return "", fmt.Errorf("api_url %q conflicts with site %q: expected host %q", apiURL, site, expectedHost)
+		}
+	}
+
+	return apiURL, nil
+}
+
+// telemetryEnabled reports whether the `telemetry { enabled = true }` block
+// was configured.
+func telemetryEnabled(d *schema.ResourceData) bool {
+	blocks := d.Get("telemetry").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return false
+	}
+	return blocks[0].(map[string]interface{})["enabled"].(bool)
+}
+
+// newDatadogHTTPClient builds a new http.Client for the community, V1, or V2
+// Datadog client (each call returns a separate client), wrapping it with the
+// debug logging transport and, when enabled, the rate-limit-aware retrying
+// transport.
+func newDatadogHTTPClient(d *schema.ResourceData) *http.Client {
+	c := cleanhttp.DefaultClient()
+	c.Timeout = time.Duration(d.Get("http_timeout").(int)) * time.Second
+	c.Transport = logging.NewTransport("Datadog", c.Transport)
+
+	if d.Get("http_retry_enabled").(bool) {
+		c.Transport = &retryingTransport{
+			next:       c.Transport,
+			maxRetries: d.Get("http_retry_max").(int),
+			maxBackoff: time.Duration(d.Get("http_retry_max_backoff").(int)) * time.Second,
+		}
+	}
+
+	return c
+}
+
 func Provider() terraform.ResourceProvider {
 	datadogProvider = &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -41,11 +105,29 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_APP_KEY", "DD_APP_KEY"}, nil),
 				Description: "(Required unless validate is false) Datadog APP key. This can also be set via the DD_APP_KEY environment variable.",
 			},
+			"api_key_source": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_API_KEY_SOURCE", "DD_API_KEY_SOURCE"}, ""),
+				Description: "A secret reference to resolve the Datadog API key from instead of `api_key`, e.g. `vault://secret/datadog#api_key`, `awssm://datadog-api-key`, `gcpsm://my-project/datadog-api-key`, `sops://secrets.enc.json#api_key`, or `exec://command args`. Takes precedence over `api_key` when both are set.",
+			},
+			"app_key_source": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_APP_KEY_SOURCE", "DD_APP_KEY_SOURCE"}, ""),
+				Description: "A secret reference to resolve the Datadog APP key from instead of `app_key`, using the same reference syntax as `api_key_source`. Takes precedence over `app_key` when both are set.",
+			},
 			"api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_HOST", "DD_HOST"}, nil),
-				Description: "The API Url. This can be also be set via the DD_HOST environment variable. Note that this URL must not end with the /api/ path. For example, https://api.datadoghq.com/ is a correct value, while https://api.datadoghq.com/api/ is not. And if you're working with \"EU\" version of Datadog, use https://api.datadoghq.eu/.",
+				Description: "The API Url. This can be also be set via the DD_HOST environment variable. Note that this URL must not end with the /api/ path. For example, https://api.datadoghq.com/ is a correct value, while https://api.datadoghq.com/api/ is not. And if you're working with \"EU\" version of Datadog, use https://api.datadoghq.eu/. Overrides `site` if both are set.",
+			},
+			"site": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_SITE", "DD_SITE"}, defaultSite),
+				Description: "The API URL Datadog site to use, in particular to access the Datadog European Union (EU) site, i.e. `datadoghq.eu`, or the Datadog US3/US5/Gov sites, e.g. `us3.datadoghq.com`, `us5.datadoghq.com`, `ddog-gov.com`. This can also be set via the DD_SITE environment variable. Defaults to `datadoghq.com`. If `api_url` is also set, `api_url` is used, and provider configuration fails when `site` is not the default and `api_url`'s host is not `api.<site>`.",
 			},
 			"validate": {
 				Type:        schema.TypeBool,
@@ -53,12 +135,86 @@ func Provider() terraform.ResourceProvider {
 				Default:     true,
 				Description: "Enables validation of the provided API and APP keys during provider initialization. Default is true. When false, api_key and app_keywon't be checked.",
 			},
+			"http_retry_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enables automatic retries with backoff when the Datadog API responds with a rate limit (429) or server (5xx) error, or when an idempotent request (GET, PUT, DELETE, HEAD, OPTIONS) fails with a transient network error. Default is true.",
+			},
+			"http_retry_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of retries for a single request when `http_retry_enabled` is true. Default is 3.",
+			},
+			"http_retry_max_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "The maximum number of seconds to wait between retries when `http_retry_enabled` is true. Default is 30.",
+			},
+			"http_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "The HTTP request timeout, in seconds, for calls made to the Datadog API. Default is 60.",
+			},
+			"auth_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      authModeAPIKey,
+				ValidateFunc: validation.StringInSlice([]string{authModeAPIKey, authModeOAuth2}, false),
+				Description:  "The authentication mode used for the v2 API client, either `api_key` or `oauth2`. When `oauth2`, `client_id`/`client_secret`/`token_url` are used to obtain and automatically refresh a bearer token instead of `app_key` for v2 API calls only; `app_key` is still required for the v1/community-client-backed resources. Default is `api_key`.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_CLIENT_ID", "DD_CLIENT_ID"}, ""),
+				Description: "The OAuth2 client ID, required when `auth_mode = \"oauth2\"`. This can also be set via the DD_CLIENT_ID environment variable.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_CLIENT_SECRET", "DD_CLIENT_SECRET"}, ""),
+				Description: "The OAuth2 client secret, required when `auth_mode = \"oauth2\"`. This can also be set via the DD_CLIENT_SECRET environment variable.",
+			},
+			"token_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DATADOG_TOKEN_URL", "DD_TOKEN_URL"}, ""),
+				Description: "The OAuth2 token endpoint of your IdP, required when `auth_mode = \"oauth2\"`.",
+			},
+			"scopes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The OAuth2 scopes to request when `auth_mode = \"oauth2\"`.",
+			},
+			"telemetry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Opt-in self-telemetry: when enabled, the provider submits its own reconcile counts, durations, and validation failures as custom metrics and events to the configured Datadog org.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Enables the self-telemetry forwarder. Default is false.",
+						},
+					},
+				},
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"datadog_dashboard":                            resourceDatadogDashboard(),
 			"datadog_dashboard_list":                       resourceDatadogDashboardList(),
 			"datadog_downtime":                             resourceDatadogDowntime(),
+			"datadog_incident":                             resourceDatadogIncident(),
 			"datadog_integration_aws":                      resourceDatadogIntegrationAws(),
 			"datadog_integration_aws_lambda_arn":           resourceDatadogIntegrationAwsLambdaArn(),
 			"datadog_integration_aws_log_collection":       resourceDatadogIntegrationAwsLogCollection(),
@@ -90,6 +246,7 @@ func Provider() terraform.ResourceProvider {
 		DataSourcesMap: map[string]*schema.Resource{
 			"datadog_dashboard":                 dataSourceDatadogDashboard(),
 			"datadog_dashboard_list":            dataSourceDatadogDashboardList(),
+			"datadog_incident":                  dataSourceDatadogIncident(),
 			"datadog_ip_ranges":                 dataSourceDatadogIpRanges(),
 			"datadog_monitor":                   dataSourceDatadogMonitor(),
 			"datadog_permissions":               dataSourceDatadogPermissions(),
@@ -112,6 +269,9 @@ type ProviderConfiguration struct {
 	AuthV1          context.Context
 	AuthV2          context.Context
 
+	// Telemetry is non-nil only when the `telemetry` block is enabled.
+	Telemetry *telemetryForwarder
+
 	now func() time.Time
 }
 
@@ -123,20 +283,51 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	apiKey := d.Get("api_key").(string)
 	appKey := d.Get("app_key").(string)
 	validate := d.Get("validate").(bool)
+	authMode := d.Get("auth_mode").(string)
+
+	// Resolved plaintext values are only ever held locally and passed to the
+	// API clients; they are never written back into ResourceData, so they
+	// never end up in Terraform state.
+	if apiKeyRef := d.Get("api_key_source").(string); apiKeyRef != "" {
+		resolved, err := secrets.Resolve(context.Background(), apiKeyRef)
+		if err != nil {
+			return nil, fmt.Errorf("resolving api_key_source: %v", err)
+		}
+		apiKey = resolved
+	}
+	if appKeyRef := d.Get("app_key_source").(string); appKeyRef != "" {
+		resolved, err := secrets.Resolve(context.Background(), appKeyRef)
+		if err != nil {
+			return nil, fmt.Errorf("resolving app_key_source: %v", err)
+		}
+		appKey = resolved
+	}
 
-	if validate && (apiKey == "" || appKey == "") {
-		return nil, errors.New("api_key and app_key must be set unless validate = false")
+	if validate && apiKey == "" {
+		return nil, errors.New("api_key must be set unless validate = false")
+	}
+	// app_key is required regardless of auth_mode: oauth2 mode only replaces
+	// the app key used by the v2 API client, not the v1/community client that
+	// the rest of the resources in this provider are still built on.
+	if validate && appKey == "" {
+		return nil, errors.New("app_key must be set unless validate = false")
+	}
+	if authMode == authModeOAuth2 {
+		if d.Get("client_id").(string) == "" || d.Get("client_secret").(string) == "" || d.Get("token_url").(string) == "" {
+			return nil, errors.New("client_id, client_secret, and token_url must all be set when auth_mode = \"oauth2\"")
+		}
+	}
+
+	apiURL, err := resolveAPIURL(d.Get("api_url").(string), d.Get("site").(string))
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize the community client
 	communityClient := datadogCommunity.NewClient(apiKey, appKey)
+	communityClient.SetBaseUrl(apiURL)
 
-	if apiURL := d.Get("api_url").(string); apiURL != "" {
-		communityClient.SetBaseUrl(apiURL)
-	}
-
-	c := cleanhttp.DefaultClient()
-	c.Transport = logging.NewTransport("Datadog", c.Transport)
+	c := newDatadogHTTPClient(d)
 	communityClient.ExtraHeader["User-Agent"] = getUserAgent(fmt.Sprintf(
 		"datadog-api-client-go/%s (go %s; os %s; arch %s)",
 		"go-datadog-api",
@@ -146,22 +337,6 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	))
 	communityClient.HttpClient = c
 
-	if validate {
-		log.Println("[INFO] Datadog client successfully initialized, now validating...")
-		ok, err := communityClient.Validate()
-		if err != nil {
-			log.Printf("[ERROR] Datadog Client validation error: %v", err)
-			return nil, err
-		} else if !ok {
-			err := errors.New(`Invalid or missing credentials provided to the Datadog Provider. Please confirm your API and APP keys are valid and are for the correct region, see https://www.terraform.io/docs/providers/datadog/ for more information on providing credentials for the Datadog Provider`)
-			log.Printf("[ERROR] Datadog Client validation error: %v", err)
-			return nil, err
-		}
-	} else {
-		log.Println("[INFO] Skipping key validation (validate = false)")
-	}
-	log.Printf("[INFO] Datadog Client successfully validated.")
-
 	// Initialize the official Datadog V1 API client
 	authV1 := context.WithValue(
 		context.Background(),
@@ -183,7 +358,8 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	configV1.SetUnstableOperationEnabled("UpdateLogsIndexOrder", true)
 	configV1.UserAgent = getUserAgent(configV1.UserAgent)
 	configV1.Debug = logging.IsDebugOrHigher()
-	if apiURL := d.Get("api_url").(string); apiURL != "" {
+	configV1.HTTPClient = newDatadogHTTPClient(d)
+	{
 		parsedApiUrl, parseErr := url.Parse(apiURL)
 		if parseErr != nil {
 			return nil, fmt.Errorf(`invalid API Url : %v`, parseErr)
@@ -191,7 +367,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		if parsedApiUrl.Host == "" || parsedApiUrl.Scheme == "" {
 			return nil, fmt.Errorf(`missing protocol or host : %v`, apiURL)
 		}
-		// If api url is passed, set and use the api name and protocol on ServerIndex{1}
+		// Use the api name and protocol derived from site/api_url on ServerIndex{1}
 		authV1 = context.WithValue(authV1, datadogV1.ContextServerIndex, 1)
 		authV1 = context.WithValue(authV1, datadogV1.ContextServerVariables, map[string]string{
 			"name":     parsedApiUrl.Host,
@@ -219,23 +395,68 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 	datadogClientV1 := datadogV1.NewAPIClient(configV1)
 
+	var telemetry *telemetryForwarder
+	if telemetryEnabled(d) {
+		telemetry = newTelemetryForwarder(datadogClientV1, authV1, []string{"provider:terraform-provider-datadog"})
+		telemetry.Start()
+
+		go func() {
+			<-datadogProvider.StopContext().Done()
+			telemetry.Stop()
+		}()
+	}
+
+	if validate {
+		log.Println("[INFO] Datadog client successfully initialized, now validating...")
+		ok, err := communityClient.Validate()
+		if err != nil {
+			log.Printf("[ERROR] Datadog Client validation error: %v", err)
+			if telemetry != nil {
+				telemetry.RecordValidationFailure("provider", err)
+			}
+			return nil, err
+		} else if !ok {
+			err := errors.New(`Invalid or missing credentials provided to the Datadog Provider. Please confirm your API and APP keys are valid and are for the correct region, see https://www.terraform.io/docs/providers/datadog/ for more information on providing credentials for the Datadog Provider`)
+			log.Printf("[ERROR] Datadog Client validation error: %v", err)
+			if telemetry != nil {
+				telemetry.RecordValidationFailure("provider", err)
+			}
+			return nil, err
+		}
+	} else {
+		log.Println("[INFO] Skipping key validation (validate = false)")
+	}
+	log.Printf("[INFO] Datadog Client successfully validated.")
+
 	// Initialize the official Datadog V2 API client
 	authV2 := context.WithValue(
 		context.Background(),
 		datadogV2.ContextAPIKeys,
 		map[string]datadogV2.APIKey{
 			"apiKeyAuth": {
-				Key: d.Get("api_key").(string),
+				Key: apiKey,
 			},
 			"appKeyAuth": {
-				Key: d.Get("app_key").(string),
+				Key: appKey,
 			},
 		},
 	)
 	configV2 := datadogV2.NewConfiguration()
 	configV2.UserAgent = getUserAgent(configV2.UserAgent)
 	configV2.Debug = logging.IsDebugOrHigher()
-	if apiURL := d.Get("api_url").(string); apiURL != "" {
+	configV2.HTTPClient = newDatadogHTTPClient(d)
+	if authMode == authModeOAuth2 {
+		// The app key pair is replaced by a bearer token obtained (and
+		// automatically refreshed) via OAuth2 client credentials; only the
+		// API key is still sent, matching what the v2 API itself expects.
+		configV2.HTTPClient = newOAuth2HTTPClient(d, configV2.HTTPClient)
+		authV2 = context.WithValue(authV2, datadogV2.ContextAPIKeys, map[string]datadogV2.APIKey{
+			"apiKeyAuth": {
+				Key: apiKey,
+			},
+		})
+	}
+	{
 		parsedApiUrl, parseErr := url.Parse(apiURL)
 		if parseErr != nil {
 			return nil, fmt.Errorf(`invalid API Url : %v`, parseErr)
@@ -243,7 +464,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		if parsedApiUrl.Host == "" || parsedApiUrl.Scheme == "" {
 			return nil, fmt.Errorf(`missing protocol or host : %v`, apiURL)
 		}
-		// If api url is passed, set and use the api name and protocol on ServerIndex{1}
+		// Use the api name and protocol derived from site/api_url on ServerIndex{1}
 		authV2 = context.WithValue(authV2, datadogV2.ContextServerIndex, 1)
 		authV2 = context.WithValue(authV2, datadogV2.ContextServerVariables, map[string]string{
 			"name":     parsedApiUrl.Host,
@@ -259,6 +480,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		DatadogClientV2: datadogClientV2,
 		AuthV1:          authV1,
 		AuthV2:          authV2,
+		Telemetry:       telemetry,
 
 		now: time.Now,
 	}, nil
@@ -0,0 +1,37 @@
+package datadog
+
+import "testing"
+
+func TestResolveAPIURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		apiURL  string
+		site    string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "", "https://api.datadoghq.com/", false},
+		{"site only", "", "datadoghq.eu", "https://api.datadoghq.eu/", false},
+		{"api_url only", "https://api.datadoghq.eu/", "", "https://api.datadoghq.eu/", false},
+		{"api_url matching site", "https://api.datadoghq.eu/", "datadoghq.eu", "https://api.datadoghq.eu/", false},
+		{"api_url conflicting with site", "https://api.datadoghq.eu/", "datadoghq.com", "", true},
+		{"invalid api_url", "://", "datadoghq.eu", "", true},
+	}
+
+	for _, tc := range cases {
+		got, err := resolveAPIURL(tc.apiURL, tc.site)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got none (result %q)", tc.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package datadog
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// knownIncidentFields are the custom incident fields this resource maps onto
+// its own top-level schema attributes; anything else is flattened back into
+// the "field" set by flattenIncidentCustomFields.
+var knownIncidentFields = map[string]bool{
+	"severity":              true,
+	"customer_impact_scope": true,
+	"commander":             true,
+	"state":                 true,
+	"archived":              true,
+}
+
+func resourceDatadogIncident() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Datadog incident resource. This can be used to create and manage Datadog incidents.",
+		Create:      resourceDatadogIncidentCreate,
+		Read:        resourceDatadogIncidentRead,
+		Update:      resourceDatadogIncidentUpdate,
+		Delete:      resourceDatadogIncidentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the incident, generally a brief summary of what's going on.",
+			},
+			"customer_impact_scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A summary of the impact customers experienced during the incident.",
+			},
+			"customer_impacted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "A flag indicating whether the incident caused customer impact.",
+			},
+			"severity": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The incident severity, for example `SEV-1` through `SEV-5` or `UNKNOWN`.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The state of the incident, one of `active`, `stable`, or `resolved`. Resolving an incident does not archive it; archiving is controlled by `archived`.",
+			},
+			"commander_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The UUID of the user designated as the incident commander.",
+			},
+			"notification_handles": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Notification handles that will be notified of the incident's state changes.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"display_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the notified handle.",
+						},
+						"handle": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The handle used for the notification, for example an email address or Slack channel.",
+						},
+					},
+				},
+			},
+			"field": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A dynamic incident field, for tracking custom incident metadata such as the affected services or detection method.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the field.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "dropdown",
+							Description: "The type of the field, one of `dropdown`, `multiselect`, `textbox`, or `metrictag`.",
+						},
+						"value": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The field's value(s).",
+						},
+					},
+				},
+			},
+			"archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the incident (and its post-mortem) has been archived. Archiving an incident is only valid once it is `resolved`.",
+			},
+		},
+	}
+}
+
+func buildDatadogIncidentCreate(d *schema.ResourceData) *datadogV2.IncidentCreateRequest {
+	customerImpacted := d.Get("customer_impacted").(bool)
+	attributes := datadogV2.NewIncidentCreateAttributesWithDefaults()
+	attributes.SetTitle(d.Get("title").(string))
+	attributes.SetCustomerImpacted(customerImpacted)
+	attributes.SetFields(buildDatadogIncidentFields(d))
+	if handles := buildDatadogIncidentNotificationHandles(d); handles != nil {
+		attributes.SetNotificationHandles(handles)
+	}
+
+	data := datadogV2.NewIncidentCreateDataWithDefaults()
+	data.SetType(datadogV2.INCIDENTTYPE_INCIDENTS)
+	data.SetAttributes(*attributes)
+
+	req := datadogV2.NewIncidentCreateRequestWithDefaults()
+	req.SetData(*data)
+
+	return req
+}
+
+func buildDatadogIncidentFields(d *schema.ResourceData) map[string]datadogV2.IncidentFieldAttributes {
+	fields := make(map[string]datadogV2.IncidentFieldAttributes)
+
+	if v, ok := d.GetOk("severity"); ok {
+		fields["severity"] = buildIncidentDropdownField(v.(string))
+	}
+	if v, ok := d.GetOk("customer_impact_scope"); ok {
+		field := datadogV2.NewIncidentFieldAttributesSingleValueWithDefaults()
+		field.SetType(datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_TEXTBOX)
+		field.SetValue(v.(string))
+		fields["customer_impact_scope"] = datadogV2.IncidentFieldAttributesSingleValueAsIncidentFieldAttributes(field)
+	}
+	if v, ok := d.GetOk("commander_user_id"); ok {
+		field := datadogV2.NewIncidentFieldAttributesSingleValueWithDefaults()
+		field.SetType(datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_TEXTBOX)
+		field.SetValue(v.(string))
+		fields["commander"] = datadogV2.IncidentFieldAttributesSingleValueAsIncidentFieldAttributes(field)
+	}
+	// "status" maps to the API's "state" field so that transitions such as
+	// active -> stable -> resolved actually reach the Incident Management API.
+	if v, ok := d.GetOk("status"); ok {
+		fields["state"] = buildIncidentDropdownField(v.(string))
+	}
+	if d.Get("archived").(bool) {
+		fields["archived"] = buildIncidentDropdownField("true")
+	}
+
+	for _, raw := range d.Get("field").(*schema.Set).List() {
+		f := raw.(map[string]interface{})
+		name := f["name"].(string)
+		values := make([]string, 0)
+		for _, val := range f["value"].([]interface{}) {
+			values = append(values, val.(string))
+		}
+		fields[name] = buildIncidentFieldByType(f["type"].(string), values)
+	}
+
+	return fields
+}
+
+// buildIncidentFieldByType builds the IncidentFieldAttributes variant
+// matching fieldType ("dropdown", "textbox", "metrictag" are single-valued;
+// "multiselect" is multi-valued), defaulting to multiselect for an unknown
+// or empty type so existing multi-value fields keep working.
+func buildIncidentFieldByType(fieldType string, values []string) datadogV2.IncidentFieldAttributes {
+	switch fieldType {
+	case "dropdown", "textbox", "metrictag":
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		field := datadogV2.NewIncidentFieldAttributesSingleValueWithDefaults()
+		field.SetType(incidentFieldSingleValueType(fieldType))
+		field.SetValue(value)
+		return datadogV2.IncidentFieldAttributesSingleValueAsIncidentFieldAttributes(field)
+	default:
+		field := datadogV2.NewIncidentFieldAttributesMultipleValueWithDefaults()
+		field.SetType(datadogV2.INCIDENTFIELDATTRIBUTESMULTIPLEVALUETYPE_MULTISELECT)
+		field.SetValue(values)
+		return datadogV2.IncidentFieldAttributesMultipleValueAsIncidentFieldAttributes(field)
+	}
+}
+
+func incidentFieldSingleValueType(fieldType string) datadogV2.IncidentFieldAttributesSingleValueType {
+	switch fieldType {
+	case "textbox":
+		return datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_TEXTBOX
+	case "metrictag":
+		return datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_METRICTAG
+	default:
+		return datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_DROPDOWN
+	}
+}
+
+func buildIncidentDropdownField(value string) datadogV2.IncidentFieldAttributes {
+	field := datadogV2.NewIncidentFieldAttributesSingleValueWithDefaults()
+	field.SetType(datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_DROPDOWN)
+	field.SetValue(value)
+	return datadogV2.IncidentFieldAttributesSingleValueAsIncidentFieldAttributes(field)
+}
+
+func buildDatadogIncidentNotificationHandles(d *schema.ResourceData) []datadogV2.IncidentNotificationHandle {
+	raw := d.Get("notification_handles").([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+
+	handles := make([]datadogV2.IncidentNotificationHandle, 0, len(raw))
+	for _, r := range raw {
+		h := r.(map[string]interface{})
+		handle := datadogV2.NewIncidentNotificationHandleWithDefaults()
+		if displayName, ok := h["display_name"].(string); ok && displayName != "" {
+			handle.SetDisplayName(displayName)
+		}
+		if value, ok := h["handle"].(string); ok && value != "" {
+			handle.SetHandle(value)
+		}
+		handles = append(handles, *handle)
+	}
+
+	return handles
+}
+
+func flattenIncidentNotificationHandles(handles []datadogV2.IncidentNotificationHandle) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(handles))
+	for _, h := range handles {
+		flattened = append(flattened, map[string]interface{}{
+			"display_name": h.GetDisplayName(),
+			"handle":       h.GetHandle(),
+		})
+	}
+	return flattened
+}
+
+func resourceDatadogIncidentCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	start := time.Now()
+	defer func() { recordReconcile(meta, "datadog_incident", start, err) }()
+
+	if err = validateIncidentArchived(d); err != nil {
+		return err
+	}
+
+	providerConf := meta.(*ProviderConfiguration)
+	datadogClientV2 := providerConf.DatadogClientV2
+	authV2 := providerConf.AuthV2
+
+	req := buildDatadogIncidentCreate(d)
+	resp, _, err := datadogClientV2.IncidentsApi.CreateIncident(authV2, *req)
+	if err != nil {
+		err = translateClientError(err, "error creating incident")
+		return err
+	}
+
+	d.SetId(resp.Data.GetId())
+
+	err = updateIncidentState(d, &resp)
+	return err
+}
+
+func resourceDatadogIncidentRead(d *schema.ResourceData, meta interface{}) (err error) {
+	start := time.Now()
+	defer func() { recordReconcile(meta, "datadog_incident", start, err) }()
+
+	providerConf := meta.(*ProviderConfiguration)
+	datadogClientV2 := providerConf.DatadogClientV2
+	authV2 := providerConf.AuthV2
+
+	resp, httpResp, err := datadogClientV2.IncidentsApi.GetIncident(authV2, d.Id())
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			log.Printf("[WARN] Incident %s not found, removing from state", d.Id())
+			d.SetId("")
+			err = nil
+			return err
+		}
+		err = translateClientError(err, "error reading incident")
+		return err
+	}
+
+	err = updateIncidentState(d, &resp)
+	return err
+}
+
+func resourceDatadogIncidentUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	start := time.Now()
+	defer func() { recordReconcile(meta, "datadog_incident", start, err) }()
+
+	if err = validateIncidentArchived(d); err != nil {
+		return err
+	}
+
+	providerConf := meta.(*ProviderConfiguration)
+	datadogClientV2 := providerConf.DatadogClientV2
+	authV2 := providerConf.AuthV2
+
+	attributes := datadogV2.NewIncidentUpdateAttributesWithDefaults()
+	attributes.SetTitle(d.Get("title").(string))
+	attributes.SetCustomerImpacted(d.Get("customer_impacted").(bool))
+	attributes.SetFields(buildDatadogIncidentFields(d))
+	if handles := buildDatadogIncidentNotificationHandles(d); handles != nil {
+		attributes.SetNotificationHandles(handles)
+	}
+
+	data := datadogV2.NewIncidentUpdateDataWithDefaults()
+	data.SetType(datadogV2.INCIDENTTYPE_INCIDENTS)
+	data.SetId(d.Id())
+	data.SetAttributes(*attributes)
+
+	req := datadogV2.NewIncidentUpdateRequestWithDefaults()
+	req.SetData(*data)
+
+	resp, _, err := datadogClientV2.IncidentsApi.UpdateIncident(authV2, d.Id(), *req)
+	if err != nil {
+		err = translateClientError(err, "error updating incident")
+		return err
+	}
+
+	err = updateIncidentState(d, &resp)
+	return err
+}
+
+// validateIncidentArchived rejects archived = true configured alongside any
+// status other than "resolved" up front, rather than silently dropping it
+// and leaving a plan that can never converge.
+func validateIncidentArchived(d *schema.ResourceData) error {
+	if d.Get("archived").(bool) && d.Get("status").(string) != "resolved" {
+		return fmt.Errorf(`archived can only be set to true when status = "resolved"`)
+	}
+	return nil
+}
+
+// flattenIncidentCustomFields flattens any field not covered by one of this
+// resource's own top-level attributes (see knownIncidentFields) back into
+// the "field" set, so drift on custom incident fields is actually detected.
+func flattenIncidentCustomFields(fields map[string]datadogV2.IncidentFieldAttributes) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0)
+	for name, field := range fields {
+		if knownIncidentFields[name] {
+			continue
+		}
+
+		if v := field.IncidentFieldAttributesSingleValue; v != nil {
+			flattened = append(flattened, map[string]interface{}{
+				"name":  name,
+				"type":  string(v.GetType()),
+				"value": []interface{}{v.GetValue()},
+			})
+			continue
+		}
+
+		if v := field.IncidentFieldAttributesMultipleValue; v != nil {
+			values := make([]interface{}, 0, len(v.GetValue()))
+			for _, val := range v.GetValue() {
+				values = append(values, val)
+			}
+			flattened = append(flattened, map[string]interface{}{
+				"name":  name,
+				"type":  string(v.GetType()),
+				"value": values,
+			})
+		}
+	}
+	return flattened
+}
+
+func resourceDatadogIncidentDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	start := time.Now()
+	defer func() { recordReconcile(meta, "datadog_incident", start, err) }()
+
+	providerConf := meta.(*ProviderConfiguration)
+	datadogClientV2 := providerConf.DatadogClientV2
+	authV2 := providerConf.AuthV2
+
+	_, err = datadogClientV2.IncidentsApi.DeleteIncident(authV2, d.Id())
+	if err != nil {
+		err = translateClientError(err, "error deleting incident")
+	}
+
+	return err
+}
+
+func updateIncidentState(d *schema.ResourceData, incident *datadogV2.IncidentResponse) error {
+	data := incident.GetData()
+	attributes := data.GetAttributes()
+
+	if err := d.Set("title", attributes.GetTitle()); err != nil {
+		return err
+	}
+	if err := d.Set("customer_impacted", attributes.GetCustomerImpacted()); err != nil {
+		return err
+	}
+	if err := d.Set("notification_handles", flattenIncidentNotificationHandles(attributes.GetNotificationHandles())); err != nil {
+		return err
+	}
+
+	fields := attributes.GetFields()
+	if severity, ok := fields["severity"]; ok {
+		if v := severity.IncidentFieldAttributesSingleValue; v != nil {
+			if err := d.Set("severity", v.GetValue()); err != nil {
+				return err
+			}
+		}
+	}
+	if scope, ok := fields["customer_impact_scope"]; ok {
+		if v := scope.IncidentFieldAttributesSingleValue; v != nil {
+			if err := d.Set("customer_impact_scope", v.GetValue()); err != nil {
+				return err
+			}
+		}
+	}
+	if commander, ok := fields["commander"]; ok {
+		if v := commander.IncidentFieldAttributesSingleValue; v != nil {
+			if err := d.Set("commander_user_id", v.GetValue()); err != nil {
+				return err
+			}
+		}
+	}
+	if state, ok := fields["state"]; ok {
+		if v := state.IncidentFieldAttributesSingleValue; v != nil {
+			if err := d.Set("status", v.GetValue()); err != nil {
+				return err
+			}
+		}
+	}
+	if archived, ok := fields["archived"]; ok {
+		if v := archived.IncidentFieldAttributesSingleValue; v != nil {
+			if err := d.Set("archived", v.GetValue() == "true"); err != nil {
+				return err
+			}
+		}
+	}
+	if err := d.Set("field", flattenIncidentCustomFields(fields)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package datadog
+
+import (
+	"testing"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestBuildDatadogIncidentFields_Status(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogIncident().Schema, map[string]interface{}{
+		"title":  "db outage",
+		"status": "resolved",
+	})
+
+	fields := buildDatadogIncidentFields(d)
+
+	state, ok := fields["state"]
+	if !ok {
+		t.Fatal("expected \"status\" to be sent to the API as the \"state\" field")
+	}
+	if got := state.IncidentFieldAttributesSingleValue.GetValue(); got != "resolved" {
+		t.Errorf("expected state value %q, got %q", "resolved", got)
+	}
+}
+
+func TestBuildIncidentFieldByType(t *testing.T) {
+	cases := []struct {
+		fieldType string
+		wantMulti bool
+	}{
+		{"dropdown", false},
+		{"textbox", false},
+		{"metrictag", false},
+		{"multiselect", true},
+		{"", true},
+	}
+
+	for _, tc := range cases {
+		field := buildIncidentFieldByType(tc.fieldType, []string{"a", "b"})
+		isMulti := field.IncidentFieldAttributesMultipleValue != nil
+		if isMulti != tc.wantMulti {
+			t.Errorf("type %q: expected multi-value=%v, got %v", tc.fieldType, tc.wantMulti, isMulti)
+		}
+		if !tc.wantMulti && field.IncidentFieldAttributesSingleValue.GetValue() != "a" {
+			t.Errorf("type %q: expected single value %q, got %q", tc.fieldType, "a", field.IncidentFieldAttributesSingleValue.GetValue())
+		}
+	}
+}
+
+func TestBuildDatadogIncidentNotificationHandles(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogIncident().Schema, map[string]interface{}{
+		"title": "db outage",
+		"notification_handles": []interface{}{
+			map[string]interface{}{"display_name": "On-call", "handle": "@oncall"},
+		},
+	})
+
+	handles := buildDatadogIncidentNotificationHandles(d)
+	if len(handles) != 1 {
+		t.Fatalf("expected 1 notification handle, got %d", len(handles))
+	}
+	if got := handles[0].GetHandle(); got != "@oncall" {
+		t.Errorf("expected handle %q, got %q", "@oncall", got)
+	}
+	if got := handles[0].GetDisplayName(); got != "On-call" {
+		t.Errorf("expected display_name %q, got %q", "On-call", got)
+	}
+}
+
+func TestBuildDatadogIncidentNotificationHandles_Empty(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogIncident().Schema, map[string]interface{}{
+		"title": "db outage",
+	})
+
+	if handles := buildDatadogIncidentNotificationHandles(d); handles != nil {
+		t.Errorf("expected nil handles when none are configured, got %v", handles)
+	}
+}
+
+func TestFlattenIncidentNotificationHandles_RoundTrip(t *testing.T) {
+	handle := datadogV2.NewIncidentNotificationHandleWithDefaults()
+	handle.SetDisplayName("On-call")
+	handle.SetHandle("@oncall")
+
+	flattened := flattenIncidentNotificationHandles([]datadogV2.IncidentNotificationHandle{*handle})
+	if len(flattened) != 1 {
+		t.Fatalf("expected 1 flattened handle, got %d", len(flattened))
+	}
+	if flattened[0]["handle"] != "@oncall" || flattened[0]["display_name"] != "On-call" {
+		t.Errorf("unexpected flattened handle: %+v", flattened[0])
+	}
+}
+
+func TestValidateIncidentArchived(t *testing.T) {
+	cases := []struct {
+		name     string
+		archived bool
+		status   string
+		wantErr  bool
+	}{
+		{"not archived, no status", false, "", false},
+		{"not archived, active", false, "active", false},
+		{"archived, resolved", true, "resolved", false},
+		{"archived, active", true, "active", true},
+		{"archived, no status", true, "", true},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceDatadogIncident().Schema, map[string]interface{}{
+			"title":    "db outage",
+			"archived": tc.archived,
+			"status":   tc.status,
+		})
+
+		err := validateIncidentArchived(d)
+		if tc.wantErr && err == nil {
+			t.Errorf("%s: expected an error, got none", tc.name)
+		}
+		if !tc.wantErr && err != nil {
+			t.Errorf("%s: expected no error, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestBuildDatadogIncidentFields_Archived(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogIncident().Schema, map[string]interface{}{
+		"title":    "db outage",
+		"status":   "resolved",
+		"archived": true,
+	})
+
+	fields := buildDatadogIncidentFields(d)
+
+	archived, ok := fields["archived"]
+	if !ok {
+		t.Fatal("expected \"archived\" to be sent to the API")
+	}
+	if got := archived.IncidentFieldAttributesSingleValue.GetValue(); got != "true" {
+		t.Errorf("expected archived value %q, got %q", "true", got)
+	}
+}
+
+func TestFlattenIncidentCustomFields(t *testing.T) {
+	severity := datadogV2.NewIncidentFieldAttributesSingleValueWithDefaults()
+	severity.SetType(datadogV2.INCIDENTFIELDATTRIBUTESSINGLEVALUETYPE_DROPDOWN)
+	severity.SetValue("SEV-1")
+
+	affectedServices := datadogV2.NewIncidentFieldAttributesMultipleValueWithDefaults()
+	affectedServices.SetType(datadogV2.INCIDENTFIELDATTRIBUTESMULTIPLEVALUETYPE_MULTISELECT)
+	affectedServices.SetValue([]string{"checkout", "billing"})
+
+	fields := map[string]datadogV2.IncidentFieldAttributes{
+		"severity":          datadogV2.IncidentFieldAttributesSingleValueAsIncidentFieldAttributes(severity),
+		"affected_services": datadogV2.IncidentFieldAttributesMultipleValueAsIncidentFieldAttributes(affectedServices),
+	}
+
+	flattened := flattenIncidentCustomFields(fields)
+	if len(flattened) != 1 {
+		t.Fatalf("expected 1 custom field (severity is a known field), got %d: %+v", len(flattened), flattened)
+	}
+	if flattened[0]["name"] != "affected_services" {
+		t.Errorf("expected custom field name %q, got %q", "affected_services", flattened[0]["name"])
+	}
+	values, ok := flattened[0]["value"].([]interface{})
+	if !ok || len(values) != 2 || values[0] != "checkout" || values[1] != "billing" {
+		t.Errorf("unexpected custom field values: %+v", flattened[0]["value"])
+	}
+}
+
+func TestUpdateIncidentState_NotificationHandles(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogIncident().Schema, map[string]interface{}{
+		"title": "db outage",
+	})
+
+	handle := datadogV2.NewIncidentNotificationHandleWithDefaults()
+	handle.SetDisplayName("On-call")
+	handle.SetHandle("@oncall")
+
+	attributes := datadogV2.NewIncidentResponseAttributesWithDefaults()
+	attributes.SetTitle("db outage")
+	attributes.SetNotificationHandles([]datadogV2.IncidentNotificationHandle{*handle})
+
+	data := datadogV2.NewIncidentResponseDataWithDefaults()
+	data.SetAttributes(*attributes)
+
+	incident := datadogV2.NewIncidentResponseWithDefaults()
+	incident.SetData(*data)
+
+	if err := updateIncidentState(d, incident); err != nil {
+		t.Fatalf("updateIncidentState returned an error: %v", err)
+	}
+
+	handles := d.Get("notification_handles").([]interface{})
+	if len(handles) != 1 {
+		t.Fatalf("expected 1 notification handle in state, got %d", len(handles))
+	}
+	got := handles[0].(map[string]interface{})
+	if got["handle"] != "@oncall" || got["display_name"] != "On-call" {
+		t.Errorf("unexpected notification handle in state: %+v", got)
+	}
+}
@@ -0,0 +1,203 @@
+package datadog
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+)
+
+const telemetryFlushInterval = 60 * time.Second
+
+// telemetryForwarder submits self-telemetry about the provider's own
+// resource reconciles back into the Datadog org it is configured against.
+// It is modeled after the Datadog Operator's metrics_forwarder: a background
+// goroutine batches counts and durations in memory and flushes them on a
+// timer, with a sync.Map caching the per-resource-type tag slices so they
+// aren't rebuilt on every reconcile.
+type telemetryForwarder struct {
+	client *datadogV1.APIClient
+	auth   context.Context
+	tags   []string
+
+	tagCache sync.Map // resourceType -> []string
+
+	mu        sync.Mutex
+	counts    map[string]int64
+	durations map[string][]float64
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+func newTelemetryForwarder(client *datadogV1.APIClient, auth context.Context, tags []string) *telemetryForwarder {
+	return &telemetryForwarder{
+		client:    client,
+		auth:      auth,
+		tags:      tags,
+		counts:    make(map[string]int64),
+		durations: make(map[string][]float64),
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+// Start launches the periodic flush loop. It must be paired with a call to
+// Stop so the final batch is flushed before the provider exits.
+func (f *telemetryForwarder) Start() {
+	go f.run()
+}
+
+// Stop signals the flush loop to flush one last time and exit, blocking
+// until it has done so.
+func (f *telemetryForwarder) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stopCh)
+	})
+	<-f.doneCh
+}
+
+func (f *telemetryForwarder) run() {
+	defer close(f.doneCh)
+
+	ticker := time.NewTicker(telemetryFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.flush()
+		case <-f.stopCh:
+			f.flush()
+			return
+		}
+	}
+}
+
+// RecordReconcile records the outcome and duration of a single resource
+// reconcile (create/read/update/delete) for the next flush.
+func (f *telemetryForwarder) RecordReconcile(resourceType, result string, duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.counts[resourceType+":"+result]++
+	f.durations[resourceType] = append(f.durations[resourceType], duration.Seconds())
+}
+
+// RecordValidationFailure submits a Datadog event immediately rather than
+// waiting for the next flush, since validation failures are rare and
+// actionable enough to warrant low latency.
+func (f *telemetryForwarder) RecordValidationFailure(resourceType string, validationErr error) {
+	body := datadogV1.NewEventCreateRequestWithDefaults()
+	body.SetTitle("terraform-provider-datadog validation failure")
+	body.SetText(validationErr.Error())
+	body.SetAlertType(datadogV1.EVENTALERTTYPE_ERROR)
+	body.SetTags(f.tagsFor(resourceType))
+
+	if _, _, err := f.client.EventsApi.CreateEvent(f.auth, *body); err != nil {
+		log.Printf("[WARN] Datadog provider telemetry: failed to submit validation failure event: %v", err)
+	}
+}
+
+// recordReconcile records the outcome and duration of a single resource
+// CRUD call with the provider's telemetry forwarder, if one is configured.
+// Resource Create/Read/Update/Delete functions call this via defer so a
+// reconcile is recorded regardless of which return path was taken.
+func recordReconcile(meta interface{}, resourceType string, start time.Time, err error) {
+	providerConf, ok := meta.(*ProviderConfiguration)
+	if !ok || providerConf.Telemetry == nil {
+		return
+	}
+
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	providerConf.Telemetry.RecordReconcile(resourceType, result, time.Since(start))
+}
+
+func (f *telemetryForwarder) tagsFor(resourceType string) []string {
+	if cached, ok := f.tagCache.Load(resourceType); ok {
+		return cached.([]string)
+	}
+
+	tags := make([]string, 0, len(f.tags)+1)
+	tags = append(tags, f.tags...)
+	tags = append(tags, "resource_type:"+resourceType)
+
+	f.tagCache.Store(resourceType, tags)
+	return tags
+}
+
+func (f *telemetryForwarder) flush() {
+	f.mu.Lock()
+	counts := f.counts
+	durations := f.durations
+	f.counts = make(map[string]int64)
+	f.durations = make(map[string][]float64)
+	f.mu.Unlock()
+
+	if len(counts) == 0 && len(durations) == 0 {
+		return
+	}
+
+	now := float64(time.Now().Unix())
+	series := make([]datadogV1.Series, 0, len(counts)+len(durations))
+
+	for key, count := range counts {
+		resourceType, result, ok := splitReconcileKey(key)
+		if !ok {
+			continue
+		}
+		series = append(series, datadogV1.Series{
+			Metric: "terraform.datadog_provider.resource.reconcile.count",
+			Type:   datadogV1.METRICSTYPE_COUNT.Ptr(),
+			Points: [][]*float64{{floatPtr(now), floatPtr(float64(count))}},
+			Tags:   seriesTagsPtr(append(f.tagsFor(resourceType), "result:"+result)),
+		})
+	}
+
+	for resourceType, samples := range durations {
+		series = append(series, datadogV1.Series{
+			Metric: "terraform.datadog_provider.resource.reconcile.duration",
+			Type:   datadogV1.METRICSTYPE_GAUGE.Ptr(),
+			Points: [][]*float64{{floatPtr(now), floatPtr(average(samples))}},
+			Tags:   seriesTagsPtr(f.tagsFor(resourceType)),
+		})
+	}
+
+	if _, _, err := f.client.MetricsApi.SubmitMetrics(f.auth, datadogV1.MetricsPayload{Series: series}); err != nil {
+		log.Printf("[WARN] Datadog provider telemetry: failed to submit metrics: %v", err)
+	}
+}
+
+func splitReconcileKey(key string) (resourceType, result string, ok bool) {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == ':' {
+			return key[:i], key[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func average(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	return sum / float64(len(samples))
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func seriesTagsPtr(tags []string) *[]string {
+	return &tags
+}
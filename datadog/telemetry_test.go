@@ -0,0 +1,46 @@
+package datadog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordReconcile_NilTelemetry(t *testing.T) {
+	providerConf := &ProviderConfiguration{}
+
+	// Must not panic when no telemetry block was configured.
+	recordReconcile(providerConf, "datadog_incident", time.Now(), nil)
+}
+
+func TestRecordReconcile_RecordsOutcome(t *testing.T) {
+	telemetry := newTelemetryForwarder(nil, nil, nil)
+	providerConf := &ProviderConfiguration{Telemetry: telemetry}
+
+	recordReconcile(providerConf, "datadog_incident", time.Now(), nil)
+	recordReconcile(providerConf, "datadog_incident", time.Now(), errorForTest)
+
+	if got := telemetry.counts["datadog_incident:ok"]; got != 1 {
+		t.Errorf("expected 1 ok reconcile, got %d", got)
+	}
+	if got := telemetry.counts["datadog_incident:error"]; got != 1 {
+		t.Errorf("expected 1 error reconcile, got %d", got)
+	}
+	if got := len(telemetry.durations["datadog_incident"]); got != 2 {
+		t.Errorf("expected 2 recorded durations, got %d", got)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	if got := average(nil); got != 0 {
+		t.Errorf("expected average of no samples to be 0, got %f", got)
+	}
+	if got := average([]float64{1, 2, 3}); got != 2 {
+		t.Errorf("expected average of [1,2,3] to be 2, got %f", got)
+	}
+}
+
+var errorForTest = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
@@ -0,0 +1,132 @@
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// retryingTransport wraps an http.RoundTripper with capped exponential
+// backoff on 429s, 5xxs, and transient network errors. It honors Datadog's
+// `X-RateLimit-Reset` and the standard `Retry-After` headers when present,
+// falling back to exponential backoff otherwise.
+type retryingTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxBackoff time.Duration
+
+	retryCount uint64
+}
+
+// RetryCount returns the number of retry attempts this transport has made
+// since it was created.
+func (t *retryingTransport) RetryCount() uint64 {
+	return atomic.LoadUint64(&t.retryCount)
+}
+
+func (t *retryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err = t.next.RoundTrip(req)
+
+		if attempt == t.maxRetries {
+			break
+		}
+
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if err != nil && !(isIdempotentMethod(req.Method) && isRetryableError(err)) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		atomic.AddUint64(&t.retryCount, 1)
+		// Log the path only: this transport also wraps the community client,
+		// which authenticates via api_key/application_key query parameters,
+		// and req.URL.String() would leak them into the debug log.
+		log.Printf("[DEBUG] Datadog: retrying %s %s (attempt %d/%d) after %s", req.Method, req.URL.Path, attempt+1, t.maxRetries, wait)
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	return resp, err
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+func isRetryableError(err error) bool {
+	return err != context.Canceled && err != context.DeadlineExceeded
+}
+
+// isIdempotentMethod reports whether method is safe to blindly retry after a
+// transport-level error (as opposed to a 429/5xx response, which is retried
+// regardless of method since the server is known to have rejected the
+// request rather than possibly-processed-then-lost-the-response). Retrying a
+// POST/PATCH after a network error risks duplicating a create that the
+// server actually completed before the response was lost.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff computes how long to wait before the next attempt, preferring the
+// server-provided `Retry-After`/`X-RateLimit-Reset` headers (both expressed
+// in seconds) over the capped exponential fallback.
+func (t *retryingTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		for _, header := range []string{"Retry-After", "X-RateLimit-Reset"} {
+			if v := resp.Header.Get(header); v != "" {
+				if seconds, err := strconv.Atoi(v); err == nil {
+					return t.capBackoff(time.Duration(seconds) * time.Second)
+				}
+			}
+		}
+	}
+
+	backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+	return t.capBackoff(backoff)
+}
+
+func (t *retryingTransport) capBackoff(d time.Duration) time.Duration {
+	if t.maxBackoff > 0 && d > t.maxBackoff {
+		return t.maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,78 @@
+package datadog
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotentMethod(t *testing.T) {
+	cases := map[string]bool{
+		http.MethodGet:     true,
+		http.MethodPut:     true,
+		http.MethodDelete:  true,
+		http.MethodHead:    true,
+		http.MethodOptions: true,
+		http.MethodPost:    false,
+		http.MethodPatch:   false,
+	}
+
+	for method, want := range cases {
+		if got := isIdempotentMethod(method); got != want {
+			t.Errorf("isIdempotentMethod(%q) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	cases := map[int]bool{
+		200: false,
+		404: false,
+		429: true,
+		500: true,
+		503: true,
+	}
+
+	for status, want := range cases {
+		if got := isRetryableStatus(status); got != want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestRetryingTransport_Backoff_HonorsRetryAfterHeader(t *testing.T) {
+	transport := &retryingTransport{maxBackoff: time.Minute}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if got := transport.backoff(0, resp); got != 5*time.Second {
+		t.Errorf("expected 5s from Retry-After, got %s", got)
+	}
+}
+
+func TestRetryingTransport_Backoff_HonorsRateLimitResetHeader(t *testing.T) {
+	transport := &retryingTransport{maxBackoff: time.Minute}
+
+	resp := &http.Response{Header: http.Header{"X-RateLimit-Reset": []string{"2"}}}
+	if got := transport.backoff(0, resp); got != 2*time.Second {
+		t.Errorf("expected 2s from X-RateLimit-Reset, got %s", got)
+	}
+}
+
+func TestRetryingTransport_Backoff_ExponentialFallback(t *testing.T) {
+	transport := &retryingTransport{maxBackoff: time.Minute}
+
+	if got := transport.backoff(0, nil); got != 1*time.Second {
+		t.Errorf("attempt 0: expected 1s, got %s", got)
+	}
+	if got := transport.backoff(2, nil); got != 4*time.Second {
+		t.Errorf("attempt 2: expected 4s, got %s", got)
+	}
+}
+
+func TestRetryingTransport_Backoff_CappedAtMaxBackoff(t *testing.T) {
+	transport := &retryingTransport{maxBackoff: 3 * time.Second}
+
+	if got := transport.backoff(10, nil); got != 3*time.Second {
+		t.Errorf("expected backoff capped at 3s, got %s", got)
+	}
+}
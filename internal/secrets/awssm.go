@@ -0,0 +1,32 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// awsSecretsManagerDecryptor resolves references of the form "awssm://name"
+// by shelling out to the AWS CLI, which is expected to already carry AWS
+// credentials (profile, environment variables, or instance role).
+type awsSecretsManagerDecryptor struct{}
+
+func (awsSecretsManagerDecryptor) Decrypt(ctx context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "awssm://")
+	if name == "" || name == ref {
+		return "", fmt.Errorf("secret reference %q is missing a secret name", ref)
+	}
+
+	cmd := exec.CommandContext(ctx, "aws", "secretsmanager", "get-secret-value",
+		"--secret-id", name, "--query", "SecretString", "--output", "text")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("aws secretsmanager get-secret-value failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
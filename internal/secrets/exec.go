@@ -0,0 +1,32 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// execDecryptor resolves references of the form "exec://command args..." by
+// running command through the shell and using its trimmed stdout as the
+// secret value. This is the escape hatch for backends without a dedicated
+// implementation (e.g. a company-internal credential helper).
+type execDecryptor struct{}
+
+func (execDecryptor) Decrypt(ctx context.Context, ref string) (string, error) {
+	command := strings.TrimPrefix(ref, "exec://")
+	if command == "" || command == ref {
+		return "", fmt.Errorf("secret reference %q is missing a command", ref)
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("exec command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// gcpSecretsManagerDecryptor resolves references of the form
+// "gcpsm://project/name" by shelling out to the `gcloud` CLI, which is
+// expected to already carry application-default or user credentials.
+type gcpSecretsManagerDecryptor struct{}
+
+func (gcpSecretsManagerDecryptor) Decrypt(ctx context.Context, ref string) (string, error) {
+	rest := strings.TrimPrefix(ref, "gcpsm://")
+	if rest == "" || rest == ref {
+		return "", fmt.Errorf("secret reference %q is missing project/name", ref)
+	}
+
+	project, name, ok := strings.Cut(rest, "/")
+	if !ok || project == "" || name == "" {
+		return "", fmt.Errorf("gcpsm secret reference must be gcpsm://project/name, got %q", ref)
+	}
+
+	cmd := exec.CommandContext(ctx, "gcloud", "secrets", "versions", "access", "latest",
+		"--secret="+name, "--project="+project)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("gcloud secrets versions access failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,72 @@
+// Package secrets resolves indirect credential references (e.g.
+// `vault://secret/datadog#api_key`) so that long-lived Datadog keys don't
+// need to live in plain HCL or TF_VAR_* environment variables.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Decryptor fetches the plaintext value a secret reference points at.
+// Implementations should treat ref as opaque beyond their own scheme and
+// must never log or persist the resolved value themselves.
+type Decryptor interface {
+	Decrypt(ctx context.Context, ref string) (string, error)
+}
+
+// backends maps a reference scheme (the part before "://") to the
+// Decryptor that handles it.
+var backends = map[string]Decryptor{
+	"vault": vaultDecryptor{},
+	"awssm": awsSecretsManagerDecryptor{},
+	"gcpsm": gcpSecretsManagerDecryptor{},
+	"sops":  sopsDecryptor{},
+	"exec":  execDecryptor{},
+}
+
+// Resolve dereferences ref (e.g. "vault://secret/datadog#api_key") using the
+// backend named by its scheme, returning the plaintext secret value. An
+// empty ref resolves to an empty string so callers can use it unconditionally
+// as a fallback when a direct value is already configured.
+func Resolve(ctx context.Context, ref string) (string, error) {
+	if ref == "" {
+		return "", nil
+	}
+
+	scheme, _, ok := strings.Cut(ref, "://")
+	if !ok {
+		return "", fmt.Errorf("secret reference %q is missing a \"scheme://\" prefix", ref)
+	}
+
+	backend, ok := backends[scheme]
+	if !ok {
+		return "", fmt.Errorf("unsupported secret backend %q in reference %q", scheme, ref)
+	}
+
+	value, err := backend.Decrypt(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving secret %q: %w", ref, err)
+	}
+
+	return value, nil
+}
+
+// splitRefPathAndFragment strips the "<scheme>://" prefix off ref and splits
+// the remainder into a path and an optional "#fragment" (used by backends
+// that address a single field within a larger secret, e.g. Vault's kv or a
+// sops-encrypted file).
+func splitRefPathAndFragment(ref, scheme string) (path string, fragment string, err error) {
+	rest := strings.TrimPrefix(ref, scheme+"://")
+	if rest == ref {
+		return "", "", fmt.Errorf("secret reference %q does not start with %q", ref, scheme+"://")
+	}
+
+	path, fragment, _ = strings.Cut(rest, "#")
+	if path == "" {
+		return "", "", fmt.Errorf("secret reference %q is missing a path", ref)
+	}
+
+	return path, fragment, nil
+}
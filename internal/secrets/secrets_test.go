@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolve_Empty(t *testing.T) {
+	got, err := Resolve(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+}
+
+func TestResolve_MissingScheme(t *testing.T) {
+	if _, err := Resolve(context.Background(), "secret/datadog#api_key"); err == nil {
+		t.Error("expected an error for a reference without a \"scheme://\" prefix")
+	}
+}
+
+func TestResolve_UnsupportedScheme(t *testing.T) {
+	if _, err := Resolve(context.Background(), "ssm://secret/datadog#api_key"); err == nil {
+		t.Error("expected an error for an unsupported backend scheme")
+	}
+}
+
+func TestSplitRefPathAndFragment(t *testing.T) {
+	cases := []struct {
+		name         string
+		ref          string
+		scheme       string
+		wantPath     string
+		wantFragment string
+		wantErr      bool
+	}{
+		{"path and fragment", "vault://secret/datadog#api_key", "vault", "secret/datadog", "api_key", false},
+		{"path only", "vault://secret/datadog", "vault", "secret/datadog", "", false},
+		{"wrong scheme prefix", "awssm://secret/datadog#api_key", "vault", "", "", true},
+		{"missing path", "vault://", "vault", "", "", true},
+	}
+
+	for _, tc := range cases {
+		path, fragment, err := splitRefPathAndFragment(tc.ref, tc.scheme)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got none", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if path != tc.wantPath || fragment != tc.wantFragment {
+			t.Errorf("%s: expected (%q, %q), got (%q, %q)", tc.name, tc.wantPath, tc.wantFragment, path, fragment)
+		}
+	}
+}
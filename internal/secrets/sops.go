@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// sopsDecryptor resolves references of the form "sops://file#key" by
+// shelling out to the `sops` CLI to decrypt file and extracting a single
+// top-level key from the result.
+type sopsDecryptor struct{}
+
+func (sopsDecryptor) Decrypt(ctx context.Context, ref string) (string, error) {
+	file, key, err := splitRefPathAndFragment(ref, "sops")
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", fmt.Errorf("sops secret reference must include a key, e.g. sops://%s#api_key", file)
+	}
+
+	cmd := exec.CommandContext(ctx, "sops", "-d", "--extract", fmt.Sprintf("[%q]", key), file)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("sops -d failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
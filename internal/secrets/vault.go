@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// vaultDecryptor resolves references of the form "vault://path/to/secret#field"
+// by shelling out to the `vault` CLI, which is expected to already be
+// authenticated (e.g. via VAULT_ADDR/VAULT_TOKEN in the environment).
+type vaultDecryptor struct{}
+
+func (vaultDecryptor) Decrypt(ctx context.Context, ref string) (string, error) {
+	path, field, err := splitRefPathAndFragment(ref, "vault")
+	if err != nil {
+		return "", err
+	}
+	if field == "" {
+		return "", fmt.Errorf("vault secret reference must include a field, e.g. vault://%s#api_key", path)
+	}
+
+	cmd := exec.CommandContext(ctx, "vault", "kv", "get", "-field="+field, path)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("vault kv get failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}